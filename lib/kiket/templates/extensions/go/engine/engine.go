@@ -0,0 +1,130 @@
+// Package engine is the CLI's in-memory transition engine: a small pluggable registry that
+// dispatches before_transition / after_transition (and future) lifecycle events to typed
+// Handlers. Generated extensions wire their own Handlers into it from main, and the
+// transitiontest package spins up the same engine in tests.
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Event is the payload delivered to the registry for a single transition lifecycle event.
+type Event struct {
+	Type    string          `json:"event_type"`
+	ID      string          `json:"id,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Result is returned by a Handler once it has finished processing an Event.
+type Result struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ErrorKind classifies why a dispatch failed, so callers can branch on it instead of
+// matching error strings.
+type ErrorKind string
+
+const (
+	ErrNoHandler     ErrorKind = "no_handler"
+	ErrDecodeFailed  ErrorKind = "decode_failed"
+	ErrHandlerFailed ErrorKind = "handler_failed"
+)
+
+// Error is a structured dispatch error distinguishing why a handler did not run successfully.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+}
+
+// Handler processes a single transition event and returns a Result or a structured Error.
+type Handler interface {
+	Execute(ctx context.Context, event Event) (Result, *Error)
+}
+
+// HandlerFunc adapts a plain function to the Handler interface, mirroring http.HandlerFunc.
+type HandlerFunc func(ctx context.Context, event Event) (Result, *Error)
+
+// Execute calls f(ctx, event).
+func (f HandlerFunc) Execute(ctx context.Context, event Event) (Result, *Error) {
+	return f(ctx, event)
+}
+
+// HookRegistry dispatches events to the Handler registered for their event type.
+type HookRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	wg       sync.WaitGroup
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// NewHookRegistry returns an empty registry ready for Register calls. parent is the basis
+// for every in-flight handler's context; canceling it (or calling Shutdown) propagates
+// cancellation to handlers that are still running.
+func NewHookRegistry(parent context.Context) *HookRegistry {
+	ctx, cancel := context.WithCancel(parent)
+	return &HookRegistry{
+		handlers: make(map[string]Handler),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Register associates a Handler with an event type, e.g. "before_transition". Registering
+// the same event type twice overwrites the previous handler.
+func (r *HookRegistry) Register(eventType string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[eventType] = h
+}
+
+// Dispatch decodes event_type from raw, looks up the matching Handler, and runs it,
+// passing through the registry's context so Shutdown can cancel in-flight work.
+func (r *HookRegistry) Dispatch(raw []byte) (Result, *Error) {
+	var event Event
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return Result{}, &Error{Kind: ErrDecodeFailed, Message: err.Error()}
+	}
+
+	r.mu.RLock()
+	h, ok := r.handlers[event.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return Result{}, &Error{Kind: ErrNoHandler, Message: fmt.Sprintf("no handler registered for event type %q", event.Type)}
+	}
+
+	r.wg.Add(1)
+	defer r.wg.Done()
+
+	result, hErr := h.Execute(r.ctx, event)
+	if hErr != nil {
+		if hErr.Kind == "" {
+			hErr.Kind = ErrHandlerFailed
+		}
+		return Result{}, hErr
+	}
+	return result, nil
+}
+
+// Shutdown cancels the context handed to in-flight handlers and blocks until they return.
+func (r *HookRegistry) Shutdown() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// Decode unmarshals an event's payload into dst, returning a structured decode Error on
+// failure so handlers don't have to wrap json.Unmarshal themselves.
+func Decode(payload json.RawMessage, dst interface{}) *Error {
+	if err := json.Unmarshal(payload, dst); err != nil {
+		return &Error{Kind: ErrDecodeFailed, Message: err.Error()}
+	}
+	return nil
+}
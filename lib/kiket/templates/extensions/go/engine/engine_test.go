@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeHandler struct {
+	called bool
+	err    *Error
+}
+
+func (f *fakeHandler) Execute(ctx context.Context, event Event) (Result, *Error) {
+	f.called = true
+	if f.err != nil {
+		return Result{}, f.err
+	}
+	return Result{Status: "ok"}, nil
+}
+
+func TestHookRegistryDispatchesToRegisteredHandler(t *testing.T) {
+	fake := &fakeHandler{}
+	r := NewHookRegistry(context.Background())
+	r.Register("before_transition", fake)
+
+	result, err := r.Dispatch([]byte(`{"event_type":"before_transition","payload":{"from":"a","to":"b"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fake.called {
+		t.Error("expected handler to be called")
+	}
+	if result.Status != "ok" {
+		t.Errorf("expected status ok, got %q", result.Status)
+	}
+}
+
+func TestHookRegistryUnknownEventType(t *testing.T) {
+	r := NewHookRegistry(context.Background())
+	_, err := r.Dispatch([]byte(`{"event_type":"unknown_event"}`))
+	if err == nil || err.Kind != ErrNoHandler {
+		t.Fatalf("expected ErrNoHandler, got %v", err)
+	}
+}
+
+func TestHookRegistryDecodeFailure(t *testing.T) {
+	r := NewHookRegistry(context.Background())
+	_, err := r.Dispatch([]byte(`not json`))
+	if err == nil || err.Kind != ErrDecodeFailed {
+		t.Fatalf("expected ErrDecodeFailed, got %v", err)
+	}
+}
+
+type testPayload struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func TestHookRegistryPayloadDecodeFailure(t *testing.T) {
+	r := NewHookRegistry(context.Background())
+	r.Register("before_transition", HandlerFunc(func(ctx context.Context, event Event) (Result, *Error) {
+		var p testPayload
+		return Result{}, Decode(event.Payload, &p)
+	}))
+
+	_, err := r.Dispatch([]byte(`{"event_type":"before_transition","payload":"not-an-object"}`))
+	if err == nil || err.Kind != ErrDecodeFailed {
+		t.Fatalf("expected ErrDecodeFailed, got %v", err)
+	}
+}
+
+func TestHookRegistryHandlerFailure(t *testing.T) {
+	fake := &fakeHandler{err: &Error{Kind: ErrHandlerFailed, Message: "boom"}}
+	r := NewHookRegistry(context.Background())
+	r.Register("before_transition", fake)
+
+	_, err := r.Dispatch([]byte(`{"event_type":"before_transition"}`))
+	if err == nil || err.Kind != ErrHandlerFailed {
+		t.Fatalf("expected ErrHandlerFailed, got %v", err)
+	}
+}
+
+func TestHookRegistryShutdownWaitsForInFlightHandlers(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	blocking := HandlerFunc(func(ctx context.Context, event Event) (Result, *Error) {
+		close(started)
+		<-release
+		close(done)
+		return Result{Status: "ok"}, nil
+	})
+
+	r := NewHookRegistry(context.Background())
+	r.Register("before_transition", blocking)
+
+	go func() {
+		r.Dispatch([]byte(`{"event_type":"before_transition"}`))
+	}()
+
+	<-started
+	close(release)
+	r.Shutdown()
+
+	select {
+	case <-done:
+	default:
+		t.Error("expected in-flight handler to finish before Shutdown returns")
+	}
+}
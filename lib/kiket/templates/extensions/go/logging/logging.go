@@ -0,0 +1,127 @@
+// Package logging provides the structured, redaction-aware event logging that the
+// extension's transition handlers use. It is modeled on Tesla fleet-telemetry's
+// logger.verbose toggle: verbose mode logs the full decoded payload, quiet mode logs only
+// enough to correlate an event after the fact.
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format selects how log entries are rendered.
+type Format string
+
+const (
+	FormatHuman Format = "human"
+	FormatJSON  Format = "json"
+)
+
+// Config controls what Logger.LogEvent logs and how.
+type Config struct {
+	// Verbose, when true, logs the full decoded payload for every event. When false, only
+	// the event type and correlation ID are logged.
+	Verbose bool
+	// Redact lists dotted key paths (e.g. "user.token") whose values are replaced with
+	// "***" before a payload is logged. Only consulted when Verbose is true.
+	Redact []string
+	// Format selects human-readable or JSON output. Defaults to FormatHuman.
+	Format Format
+}
+
+// Logger logs transition events according to a Config.
+type Logger struct {
+	cfg Config
+	out io.Writer
+}
+
+// New returns a Logger that writes to out according to cfg.
+func New(cfg Config, out io.Writer) *Logger {
+	if cfg.Format == "" {
+		cfg.Format = FormatHuman
+	}
+	return &Logger{cfg: cfg, out: out}
+}
+
+// LogEvent logs a single transition lifecycle event. In verbose mode the decoded payload
+// (with Redact paths applied) is included; otherwise only eventType and correlationID are.
+func (l *Logger) LogEvent(eventType, correlationID string, payload json.RawMessage) {
+	fields := map[string]interface{}{
+		"event_type":     eventType,
+		"correlation_id": correlationID,
+	}
+
+	if l.cfg.Verbose {
+		var decoded map[string]interface{}
+		if len(payload) > 0 {
+			_ = json.Unmarshal(payload, &decoded)
+		}
+		fields["payload"] = redact(decoded, l.cfg.Redact)
+	}
+
+	l.write(fields)
+}
+
+func (l *Logger) write(fields map[string]interface{}) {
+	if l.cfg.Format == FormatJSON {
+		b, err := json.Marshal(fields)
+		if err != nil {
+			fmt.Fprintf(l.out, "logging: marshal error: %v\n", err)
+			return
+		}
+		fmt.Fprintln(l.out, string(b))
+		return
+	}
+
+	parts := []string{
+		fmt.Sprintf("event_type=%v", fields["event_type"]),
+		fmt.Sprintf("correlation_id=%v", fields["correlation_id"]),
+	}
+	if payload, ok := fields["payload"]; ok {
+		parts = append(parts, fmt.Sprintf("payload=%v", payload))
+	}
+	fmt.Fprintln(l.out, strings.Join(parts, " "))
+}
+
+// redact returns a deep copy of data with every dotted path in paths replaced by "***".
+// Missing paths are ignored.
+func redact(data map[string]interface{}, paths []string) map[string]interface{} {
+	out := deepCopy(data)
+	for _, path := range paths {
+		redactPath(out, strings.Split(path, "."))
+	}
+	return out
+}
+
+func redactPath(m map[string]interface{}, keys []string) {
+	if m == nil || len(keys) == 0 {
+		return
+	}
+	key := keys[0]
+	if len(keys) == 1 {
+		if _, ok := m[key]; ok {
+			m[key] = "***"
+		}
+		return
+	}
+	if next, ok := m[key].(map[string]interface{}); ok {
+		redactPath(next, keys[1:])
+	}
+}
+
+func deepCopy(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return m
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return m
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogEventNonVerboseOmitsPayload(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Verbose: false}, &buf)
+
+	l.LogEvent("before_transition", "corr-1", []byte(`{"from":"pending","to":"active"}`))
+
+	out := buf.String()
+	if !strings.Contains(out, "event_type=before_transition") {
+		t.Errorf("expected event_type in output, got %q", out)
+	}
+	if strings.Contains(out, "payload=") {
+		t.Errorf("expected no payload in non-verbose output, got %q", out)
+	}
+}
+
+func TestLogEventVerboseIncludesPayload(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Verbose: true}, &buf)
+
+	l.LogEvent("before_transition", "corr-1", []byte(`{"from":"pending","to":"active"}`))
+
+	out := buf.String()
+	if !strings.Contains(out, "payload=") {
+		t.Errorf("expected payload in verbose output, got %q", out)
+	}
+	if !strings.Contains(out, "pending") {
+		t.Errorf("expected decoded field in verbose output, got %q", out)
+	}
+}
+
+func TestLogEventRedactsConfiguredPaths(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Verbose: true, Redact: []string{"user.token"}}, &buf)
+
+	l.LogEvent("before_transition", "corr-1", []byte(`{"user":{"token":"secret","name":"ada"}}`))
+
+	out := buf.String()
+	if strings.Contains(out, "secret") {
+		t.Errorf("expected redacted token to be hidden, got %q", out)
+	}
+	if !strings.Contains(out, "***") {
+		t.Errorf("expected redaction marker in output, got %q", out)
+	}
+	if !strings.Contains(out, "ada") {
+		t.Errorf("expected non-redacted field to survive, got %q", out)
+	}
+}
+
+func TestLogEventJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Verbose: false, Format: FormatJSON}, &buf)
+
+	l.LogEvent("after_transition", "corr-2", nil)
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "{") {
+		t.Errorf("expected JSON output, got %q", out)
+	}
+	if !strings.Contains(out, `"event_type":"after_transition"`) {
+		t.Errorf("expected event_type field in JSON output, got %q", out)
+	}
+}
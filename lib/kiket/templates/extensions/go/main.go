@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"kiket-extension/engine"
+	"kiket-extension/logging"
+)
+
+// TransitionPayload is the typed shape carried by before_transition and after_transition
+// events.
+type TransitionPayload struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// beforeTransitionHandler runs prior to a state transition being committed.
+type beforeTransitionHandler struct {
+	logger *logging.Logger
+}
+
+func (h beforeTransitionHandler) Execute(ctx context.Context, event engine.Event) (engine.Result, *engine.Error) {
+	h.logger.LogEvent(event.Type, event.ID, event.Payload)
+
+	var p TransitionPayload
+	if err := engine.Decode(event.Payload, &p); err != nil {
+		return engine.Result{}, err
+	}
+	return engine.Result{Status: "ok", Message: fmt.Sprintf("before transition %s -> %s", p.From, p.To)}, nil
+}
+
+// afterTransitionHandler runs once a state transition has been committed.
+type afterTransitionHandler struct {
+	logger *logging.Logger
+}
+
+func (h afterTransitionHandler) Execute(ctx context.Context, event engine.Event) (engine.Result, *engine.Error) {
+	h.logger.LogEvent(event.Type, event.ID, event.Payload)
+
+	var p TransitionPayload
+	if err := engine.Decode(event.Payload, &p); err != nil {
+		return engine.Result{}, err
+	}
+	return engine.Result{Status: "ok", Message: fmt.Sprintf("after transition %s -> %s", p.From, p.To)}, nil
+}
+
+// newDefaultRegistry builds the engine.HookRegistry this extension ships with out of the
+// box. Generated extensions are expected to add their own Register calls alongside these.
+func newDefaultRegistry(ctx context.Context, logCfg logging.Config) *engine.HookRegistry {
+	logger := logging.New(logCfg, os.Stdout)
+
+	r := engine.NewHookRegistry(ctx)
+	r.Register("before_transition", beforeTransitionHandler{logger: logger})
+	r.Register("after_transition", afterTransitionHandler{logger: logger})
+	return r
+}
+
+// loggerConfigFromEnv builds a logging.Config from KIKET_LOG_VERBOSE, KIKET_LOG_FORMAT
+// ("human" or "json"), and KIKET_LOG_REDACT (comma-separated dotted key paths).
+func loggerConfigFromEnv() logging.Config {
+	cfg := logging.Config{Format: logging.FormatHuman}
+
+	if v := os.Getenv("KIKET_LOG_VERBOSE"); v != "" {
+		cfg.Verbose, _ = strconv.ParseBool(v)
+	}
+	if f := os.Getenv("KIKET_LOG_FORMAT"); f == "json" {
+		cfg.Format = logging.FormatJSON
+	}
+	if r := os.Getenv("KIKET_LOG_REDACT"); r != "" {
+		cfg.Redact = strings.Split(r, ",")
+	}
+	return cfg
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry := newDefaultRegistry(ctx, loggerConfigFromEnv())
+	defer registry.Shutdown()
+
+	decoder := json.NewDecoder(os.Stdin)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			break
+		}
+		result, hookErr := registry.Dispatch(raw)
+		if hookErr != nil {
+			fmt.Fprintf(os.Stderr, "dispatch error: %v\n", hookErr)
+			continue
+		}
+		fmt.Fprintln(os.Stdout, result.Message)
+	}
+}
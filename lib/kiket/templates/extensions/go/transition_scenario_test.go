@@ -0,0 +1,29 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"kiket-extension/logging"
+	"kiket-extension/transitiontest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(transitiontest.Main(m))
+}
+
+func TestFullBeforeAfterTransitionCycle(t *testing.T) {
+	transitiontest.Run(t, func(env *transitiontest.Env) {
+		logger := logging.New(logging.Config{}, io.Discard)
+		env.RegisterHandler("before_transition", beforeTransitionHandler{logger: logger})
+		env.RegisterHandler("after_transition", afterTransitionHandler{logger: logger})
+
+		env.FireEvent("before_transition", map[string]string{"from": "pending", "to": "active"})
+		env.FireEvent("after_transition", map[string]string{"from": "pending", "to": "active"})
+
+		env.ExpectHookCalled("before_transition")
+		env.ExpectHookCalled("after_transition")
+		env.ExpectTransition("pending", "active")
+	})
+}
@@ -0,0 +1,161 @@
+// Package transitiontest is a gopls-regtest-style harness for exercising the CLI's
+// transition engine end to end instead of asserting on internal state by hand.
+//
+// A scenario looks like:
+//
+//	func TestMain(m *testing.M) { os.Exit(transitiontest.Main(m)) }
+//
+//	func TestBeforeAfterCycle(t *testing.T) {
+//		transitiontest.Run(t, func(env *transitiontest.Env) {
+//			env.FireEvent("before_transition", map[string]string{"from": "pending", "to": "active"})
+//			env.FireEvent("after_transition", map[string]string{"from": "pending", "to": "active"})
+//
+//			env.ExpectHookCalled("before_transition")
+//			env.ExpectHookCalled("after_transition")
+//			env.ExpectTransition("pending", "active")
+//		})
+//	}
+//
+// To add a new scenario, write an ordinary test function, call transitiontest.Run with a
+// closure that drives the Env, and use FireEvent/Expect*/AwaitCondition to describe the
+// behavior under test. Env.RegisterHandler lets a scenario swap in its own Handler for an
+// event type when the default recording handler isn't enough.
+package transitiontest
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"kiket-extension/engine"
+)
+
+const defaultAwaitTimeout = 2 * time.Second
+
+type hookCall struct {
+	name    string
+	payload map[string]interface{}
+}
+
+// Env is an in-memory instance of the transition engine plus the bookkeeping a scenario
+// needs to assert on what happened.
+type Env struct {
+	t        *testing.T
+	registry *engine.HookRegistry
+
+	mu    sync.Mutex
+	calls []hookCall
+}
+
+// Main runs m, same as testing.M.Run, and is meant to be called from a package's TestMain
+// so future setup/teardown shared across transitiontest scenarios has a single place to live.
+func Main(m *testing.M) int {
+	return m.Run()
+}
+
+// Run executes scenario against a fresh Env backed by its own in-memory transition engine.
+func Run(t *testing.T, scenario func(env *Env)) {
+	t.Helper()
+	scenario(newEnv(t))
+}
+
+func newEnv(t *testing.T) *Env {
+	t.Helper()
+	env := &Env{registry: engine.NewHookRegistry(context.Background()), t: t}
+	env.registry.Register("before_transition", env.recordingHandler("before_transition"))
+	env.registry.Register("after_transition", env.recordingHandler("after_transition"))
+	t.Cleanup(env.registry.Shutdown)
+	return env
+}
+
+func (e *Env) recordingHandler(name string) engine.Handler {
+	return engine.HandlerFunc(func(ctx context.Context, event engine.Event) (engine.Result, *engine.Error) {
+		e.record(name, event)
+		return engine.Result{Status: "ok"}, nil
+	})
+}
+
+func (e *Env) record(name string, event engine.Event) {
+	var payload map[string]interface{}
+	if len(event.Payload) > 0 {
+		_ = engine.Decode(event.Payload, &payload)
+	}
+	e.mu.Lock()
+	e.calls = append(e.calls, hookCall{name: name, payload: payload})
+	e.mu.Unlock()
+}
+
+// RegisterHandler installs h for eventType, replacing the harness's default recording
+// handler. h still runs under recording, so ExpectHookCalled keeps working.
+func (e *Env) RegisterHandler(eventType string, h engine.Handler) {
+	e.registry.Register(eventType, engine.HandlerFunc(func(ctx context.Context, event engine.Event) (engine.Result, *engine.Error) {
+		result, err := h.Execute(ctx, event)
+		e.record(eventType, event)
+		return result, err
+	}))
+}
+
+// FireEvent marshals payload and dispatches it through the in-memory engine as an event of
+// the given type, failing the test if the engine rejects or errors on it.
+func (e *Env) FireEvent(eventType string, payload interface{}) {
+	e.t.Helper()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		e.t.Fatalf("transitiontest: marshal payload: %v", err)
+	}
+	raw, err := json.Marshal(struct {
+		EventType string          `json:"event_type"`
+		Payload   json.RawMessage `json:"payload"`
+	}{EventType: eventType, Payload: body})
+	if err != nil {
+		e.t.Fatalf("transitiontest: marshal event: %v", err)
+	}
+	if _, hookErr := e.registry.Dispatch(raw); hookErr != nil {
+		e.t.Fatalf("transitiontest: dispatch %s: %v", eventType, hookErr)
+	}
+}
+
+// ExpectTransition asserts that some recorded event carried a payload with the given "from"
+// and "to" fields.
+func (e *Env) ExpectTransition(from, to string) {
+	e.t.Helper()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range e.calls {
+		if c.payload["from"] == from && c.payload["to"] == to {
+			return
+		}
+	}
+	e.t.Errorf("transitiontest: no recorded transition %s -> %s", from, to)
+}
+
+// ExpectHookCalled asserts that the named hook (its event type) ran at least once.
+func (e *Env) ExpectHookCalled(name string) {
+	e.t.Helper()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range e.calls {
+		if c.name == name {
+			return
+		}
+	}
+	e.t.Errorf("transitiontest: hook %q was never called", name)
+}
+
+// AwaitCondition polls cond until it returns true, failing the test if it doesn't within a
+// short default timeout. Useful for asserting on side effects of asynchronous handlers.
+func (e *Env) AwaitCondition(cond func() bool) {
+	e.t.Helper()
+	deadline := time.Now().Add(defaultAwaitTimeout)
+	for {
+		if cond() {
+			return
+		}
+		if time.Now().After(deadline) {
+			e.t.Fatalf("transitiontest: condition not met within %s", defaultAwaitTimeout)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}